@@ -0,0 +1,105 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/lucadagati/retrospect/FaaS4Things/functions/filecache"
+	"github.com/nuclio/nuclio-sdk-go"
+)
+
+const maxBytesParam = "max_bytes"
+
+// HandlerOn - Complessità O(n) - Legge il file una sola volta
+// La complessità è lineare rispetto alla dimensione del file
+func Handler(context *nuclio.Context, event nuclio.Event) (interface{}, error) {
+	if maxBytes, ok := requestedMaxBytes(event); ok {
+		return boundedResponse(maxBytes)
+	}
+
+	// Leggiamo il file una volta - O(n), servito dalla cache sul warm path
+	fileContent, err := filecache.Get("file.txt")
+	if err != nil {
+		return nuclio.Response{
+			StatusCode:  500,
+			ContentType: "application/text",
+			Body:        []byte("Errore nella lettura del file: " + err.Error()),
+		}, err
+	}
+
+	hits, misses := filecache.Stats()
+	context.Logger.InfoWith("filecache stats", "hits", hits, "misses", misses)
+
+	// Eseguiamo "cat file.txt" una volta
+	return nuclio.Response{
+		StatusCode:  200,
+		ContentType: "application/text",
+		Body:        fileContent,
+	}, nil
+}
+
+// requestedMaxBytes looks for a max_bytes limit on the header first, then the
+// query string, so callers can cap the response size without loading the
+// whole file into memory.
+func requestedMaxBytes(event nuclio.Event) (int64, bool) {
+	raw := event.GetHeaderString(maxBytesParam)
+	if raw == "" {
+		raw = event.GetFieldString(maxBytesParam)
+	}
+	if raw == "" {
+		return 0, false
+	}
+
+	maxBytes, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || maxBytes <= 0 {
+		return 0, false
+	}
+	return maxBytes, true
+}
+
+// boundedResponse streams at most maxBytes from file.txt, flagging the
+// response as truncated when the file is larger than the limit.
+func boundedResponse(maxBytes int64) (interface{}, error) {
+	f, err := os.Open("file.txt")
+	if err != nil {
+		return nuclio.Response{
+			StatusCode:  500,
+			ContentType: "application/text",
+			Body:        []byte("Errore nella lettura del file: " + err.Error()),
+		}, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nuclio.Response{
+			StatusCode:  500,
+			ContentType: "application/text",
+			Body:        []byte("Errore nella lettura del file: " + err.Error()),
+		}, err
+	}
+
+	body, err := io.ReadAll(io.LimitReader(f, maxBytes))
+	if err != nil {
+		return nuclio.Response{
+			StatusCode:  500,
+			ContentType: "application/text",
+			Body:        []byte("Errore nella lettura del file: " + err.Error()),
+		}, err
+	}
+
+	headers := map[string]interface{}{
+		"Content-Length": strconv.Itoa(len(body)),
+	}
+	if info.Size() > maxBytes {
+		headers["X-Truncated"] = "true"
+	}
+
+	return nuclio.Response{
+		StatusCode:  200,
+		ContentType: "application/text",
+		Headers:     headers,
+		Body:        body,
+	}, nil
+}
@@ -0,0 +1,197 @@
+// Package eventstore persists rabbitMQ events as newline-delimited JSON,
+// rotating files by size and date instead of growing a single comma-joined
+// /tmp/events.json forever. Appends are serialized in-process with a mutex
+// and across processes with an flock on the active file, so multiple
+// nuclio worker processes sharing the same /tmp volume can append safely.
+// Reads are streamed back out as a proper JSON array without loading the
+// whole log into memory.
+package eventstore
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const baseName = "events"
+
+// dir and maxFileBytes are vars rather than consts so tests can point the
+// store at a scratch directory and force rotation without writing ~10MB of
+// fixtures.
+var (
+	dir          = "/tmp"
+	maxFileBytes = int64(10 * 1024 * 1024) // rotate once a file would exceed ~10MB
+)
+
+var (
+	mu          sync.Mutex
+	current     *os.File
+	currentDate string
+	currentSeq  int
+	currentSize int64
+)
+
+// Append writes body as one ndjson line, rotating the current file by size
+// or date first if needed. The write is wrapped in an flock on the active
+// file so that other processes appending to the same path (e.g. another
+// nuclio worker sharing the volume) can't interleave partial writes.
+func Append(body []byte) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err := ensureOpenLocked(int64(len(body)) + 1); err != nil {
+		return err
+	}
+
+	if err := syscall.Flock(int(current.Fd()), syscall.LOCK_EX); err != nil {
+		return err
+	}
+	defer syscall.Flock(int(current.Fd()), syscall.LOCK_UN)
+
+	line := append(append([]byte{}, body...), '\n')
+	n, err := current.Write(line)
+	if err != nil {
+		return err
+	}
+	currentSize += int64(n)
+	return nil
+}
+
+// ensureOpenLocked opens today's file, rotating to the next sequence number
+// if the date changed or the current file would grow past maxFileBytes.
+// Callers must hold mu.
+func ensureOpenLocked(incoming int64) error {
+	today := time.Now().Format("20060102")
+
+	needsRotate := current == nil || today != currentDate || currentSize+incoming > maxFileBytes
+	if !needsRotate {
+		return nil
+	}
+
+	if today != currentDate {
+		currentDate = today
+		currentSeq = 0
+	} else {
+		currentSeq++
+	}
+
+	if current != nil {
+		if err := current.Close(); err != nil {
+			return err
+		}
+	}
+
+	path, err := nextAvailablePath(currentDate, &currentSeq)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	current = f
+	currentSize = info.Size()
+	return nil
+}
+
+// nextAvailablePath advances seq past any file that already exists for date,
+// so restarting the process never clobbers a previous rotation. The sequence
+// is zero-padded so lexicographic and chronological order agree once a busy
+// day rotates past 9 files (events-20060102-0010.ndjson must sort after
+// -0009, not before -0002).
+func nextAvailablePath(date string, seq *int) (string, error) {
+	for {
+		path := filepath.Join(dir, fmt.Sprintf("%s-%s-%04d.ndjson", baseName, date, *seq))
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			return path, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if info.Size() < maxFileBytes {
+			return path, nil
+		}
+		*seq++
+	}
+}
+
+// StreamAll writes every stored event to w as a single JSON array, reading
+// the rotated ndjson files one line at a time rather than buffering the
+// whole log in memory. Callers that need the result written incrementally
+// to an HTTP response should pass the writer side of a
+// nuclio.ResponseStream so nothing is buffered on either end.
+func StreamAll(w io.Writer) error {
+	paths, err := rotatedPaths()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write([]byte("[")); err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	wroteAny := false
+	for _, path := range paths {
+		if err := streamFile(path, w, enc, &wroteAny); err != nil {
+			return err
+		}
+	}
+
+	_, err = w.Write([]byte("]"))
+	return err
+}
+
+func streamFile(path string, w io.Writer, enc *json.Encoder, wroteAny *bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if *wroteAny {
+			if _, err := w.Write([]byte(",")); err != nil {
+				return err
+			}
+		}
+		if err := enc.Encode(json.RawMessage(line)); err != nil {
+			return err
+		}
+		*wroteAny = true
+	}
+	return scanner.Err()
+}
+
+// rotatedPaths returns every events-*.ndjson file in chronological order.
+// This relies on nextAvailablePath zero-padding the sequence number so that
+// sort.Strings (lexicographic) agrees with numeric/chronological order.
+func rotatedPaths() ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, baseName+"-*.ndjson"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
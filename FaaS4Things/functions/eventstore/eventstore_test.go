@@ -0,0 +1,118 @@
+package eventstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// resetForTest points the store at a scratch directory with a tiny rotation
+// threshold and clears in-process state left over from previous tests.
+func resetForTest(t *testing.T) {
+	t.Helper()
+	dir = t.TempDir()
+	maxFileBytes = 16 // force rotation after a couple of small events
+
+	mu.Lock()
+	if current != nil {
+		current.Close()
+	}
+	current = nil
+	currentDate = ""
+	currentSeq = 0
+	currentSize = 0
+	mu.Unlock()
+}
+
+func TestAppendRotatesBySizeAndStreamAllReadsThemAllBack(t *testing.T) {
+	resetForTest(t)
+
+	events := []string{
+		`{"id":1}`,
+		`{"id":2}`,
+		`{"id":3}`,
+		`{"id":4}`,
+	}
+	for _, e := range events {
+		if err := Append([]byte(e)); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	paths, err := rotatedPaths()
+	if err != nil {
+		t.Fatalf("rotatedPaths: %v", err)
+	}
+	if len(paths) < 2 {
+		t.Fatalf("expected the tiny maxFileBytes to force at least 2 rotated files, got %d: %v", len(paths), paths)
+	}
+
+	var buf bytes.Buffer
+	if err := StreamAll(&buf); err != nil {
+		t.Fatalf("StreamAll: %v", err)
+	}
+
+	var got []map[string]int
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("StreamAll output is not a valid JSON array: %v\noutput: %s", err, buf.String())
+	}
+	if len(got) != len(events) {
+		t.Fatalf("got %d events, want %d", len(got), len(events))
+	}
+	for i, e := range got {
+		if e["id"] != i+1 {
+			t.Fatalf("event %d: got id %d, want %d", i, e["id"], i+1)
+		}
+	}
+}
+
+func TestStreamAllOrdersDoubleDigitRotationsChronologically(t *testing.T) {
+	resetForTest(t)
+	maxFileBytes = 1 // force a new file per event, well past the 9->10 boundary
+
+	const n = 12
+	for i := 1; i <= n; i++ {
+		if err := Append([]byte(fmt.Sprintf(`{"id":%d}`, i))); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	paths, err := rotatedPaths()
+	if err != nil {
+		t.Fatalf("rotatedPaths: %v", err)
+	}
+	if len(paths) < 11 {
+		t.Fatalf("expected at least 11 rotated files to exercise the double-digit case, got %d: %v", len(paths), paths)
+	}
+
+	var buf bytes.Buffer
+	if err := StreamAll(&buf); err != nil {
+		t.Fatalf("StreamAll: %v", err)
+	}
+
+	var got []map[string]int
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("StreamAll output is not a valid JSON array: %v\noutput: %s", err, buf.String())
+	}
+	if len(got) != n {
+		t.Fatalf("got %d events, want %d", len(got), n)
+	}
+	for i, e := range got {
+		if e["id"] != i+1 {
+			t.Fatalf("events out of chronological order: event %d has id %d, want %d", i, e["id"], i+1)
+		}
+	}
+}
+
+func TestStreamAllOnEmptyStoreReturnsEmptyArray(t *testing.T) {
+	resetForTest(t)
+
+	var buf bytes.Buffer
+	if err := StreamAll(&buf); err != nil {
+		t.Fatalf("StreamAll: %v", err)
+	}
+	if buf.String() != "[]" {
+		t.Fatalf("got %q, want %q", buf.String(), "[]")
+	}
+}
@@ -0,0 +1,61 @@
+// Package filecache provides a small in-memory cache for file contents,
+// keyed by path and invalidated via os.Stat-based mtime/size checks. It lets
+// handlers that read the same file repeatedly (e.g. in a loop) avoid paying
+// for disk I/O on every call.
+package filecache
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type entry struct {
+	content []byte
+	modTime time.Time
+	size    int64
+}
+
+var (
+	mu    sync.RWMutex
+	cache = map[string]entry{}
+
+	hits   uint64
+	misses uint64
+)
+
+// Get returns the contents of path, serving from cache when the file's
+// mtime and size have not changed since the last read.
+func Get(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	mu.RLock()
+	e, ok := cache[path]
+	mu.RUnlock()
+	if ok && e.modTime.Equal(info.ModTime()) && e.size == info.Size() {
+		atomic.AddUint64(&hits, 1)
+		return e.content, nil
+	}
+
+	atomic.AddUint64(&misses, 1)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	mu.Lock()
+	cache[path] = entry{content: content, modTime: info.ModTime(), size: info.Size()}
+	mu.Unlock()
+
+	return content, nil
+}
+
+// Stats returns the cumulative hit/miss counters, suitable for surfacing
+// through a Prometheus-style counter or a handler's logger.
+func Stats() (hitCount, missCount uint64) {
+	return atomic.LoadUint64(&hits), atomic.LoadUint64(&misses)
+}
@@ -0,0 +1,56 @@
+package filecache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetServesFromCacheUntilFileChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(path, []byte("hello"), 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	content, err := Get(path)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if string(content) != "hello" {
+		t.Fatalf("got %q, want %q", content, "hello")
+	}
+	_, missesAfterFirst := Stats()
+
+	if _, err := Get(path); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	hits, misses := Stats()
+	if misses != missesAfterFirst {
+		t.Fatalf("expected no new miss on unchanged file, misses went from %d to %d", missesAfterFirst, misses)
+	}
+	if hits == 0 {
+		t.Fatalf("expected at least one cache hit")
+	}
+
+	// Changing size and mtime should invalidate the cached entry.
+	if err := os.WriteFile(path, []byte("hello, world"), 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+
+	content, err = Get(path)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if string(content) != "hello, world" {
+		t.Fatalf("got %q, want %q", content, "hello, world")
+	}
+	_, missesAfterChange := Stats()
+	if missesAfterChange != missesAfterFirst+1 {
+		t.Fatalf("expected exactly one new miss after modifying the file, misses went from %d to %d", missesAfterFirst, missesAfterChange)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	stdcontext "context"
+	"net/http"
+
+	"github.com/lucadagati/retrospect/FaaS4Things/functions/eventstore"
+	"github.com/lucadagati/retrospect/FaaS4Things/functions/httpclient"
+	"github.com/nuclio/nuclio-sdk-go"
+)
+
+const iotronicServiceURL = "http://10.43.100.186:50061/fn1"
+
+func Handler(context *nuclio.Context, event nuclio.Event) (interface{}, error) {
+	context.Logger.InfoWith("Received event", "body", string(event.GetBody()))
+	// if we got the event from rabbit
+	if event.GetTriggerInfo().GetClass() == "async" && event.GetTriggerInfo().GetKind() == "rabbitMq" {
+		// Effettua la chiamata HTTP GET al servizio iotronic-wstun, con retry e backoff.
+		// nuclio.Event/Context expose no invocation deadline to derive a context from,
+		// so we fall back to a fixed budget for the whole retry loop instead.
+		ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), httpclient.OverallTimeout)
+		resp, err := httpclient.GetWithRetry(ctx, iotronicServiceURL, context.Logger)
+		cancel()
+		if err != nil {
+			context.Logger.ErrorWith("Failed to call iotronic-wstun service", "error", err)
+			// Continua l'esecuzione anche in caso di errore nella chiamata HTTP
+		} else {
+			defer resp.Body.Close()
+			context.Logger.InfoWith("Successfully called iotronic-wstun service", "statusCode", resp.StatusCode)
+		}
+
+		if err := eventstore.Append(event.GetBody()); err != nil {
+			return nil, err
+		}
+		// all's well
+		return nil, nil
+	}
+	// stream every stored event back as a JSON array without buffering the
+	// whole log in memory
+	stream := nuclio.NewResponseStream("application/json", nil, http.StatusOK)
+	go func() {
+		defer stream.StopStreaming()
+		if err := eventstore.StreamAll(stream.GetWriter()); err != nil {
+			context.Logger.ErrorWith("Failed to stream events", "error", err)
+		}
+	}()
+	return stream, nil
+}
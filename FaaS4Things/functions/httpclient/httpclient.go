@@ -0,0 +1,110 @@
+// Package httpclient provides a shared, connection-reusing HTTP client for
+// calling out to other services from a handler, with retries and exponential
+// backoff+jitter instead of the stdlib default client's one-shot behaviour.
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/nuclio/logger"
+)
+
+const (
+	// OverallTimeout bounds the whole retry loop for a single call,
+	// including backoff waits between attempts. Ideally this would be
+	// derived from the inbound nuclio event's invocation deadline, but
+	// nuclio.Event/Context expose no such deadline, so callers are expected
+	// to build ctx from this fixed budget instead (e.g. via
+	// context.WithTimeout(context.Background(), OverallTimeout)).
+	OverallTimeout = 5 * time.Second
+
+	requestTimeout = 1 * time.Second
+	maxRetries     = 3
+	baseBackoff    = 50 * time.Millisecond
+)
+
+// Client is initialized once and reused across invocations so idle
+// connections stay warm between calls.
+var Client = &http.Client{
+	Timeout: requestTimeout,
+	Transport: &http.Transport{
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+	},
+}
+
+// GetWithRetry issues a GET against url, retrying on transport errors and
+// non-2xx responses with exponential backoff and jitter. ctx should carry the
+// deadline for the whole call, including retries — the nuclio SDK doesn't
+// expose the inbound event's deadline, so in practice ctx is just
+// OverallTimeout off context.Background() rather than something derived
+// from the event. On a non-2xx response the body is drained before closing
+// so the underlying connection can be reused.
+func GetWithRetry(ctx context.Context, url string, log logger.Logger) (*http.Response, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			if err := wait(ctx, backoff(attempt)); err != nil {
+				return nil, err
+			}
+		}
+
+		start := time.Now()
+		resp, err := doOnce(ctx, url)
+		latency := time.Since(start)
+
+		if err != nil {
+			lastErr = err
+			if log != nil {
+				log.WarnWith("iotronic call failed, retrying", "attempt", attempt, "latency", latency, "error", err)
+			}
+			continue
+		}
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			if log != nil {
+				log.InfoWith("iotronic call succeeded", "attempt", attempt, "latency", latency, "statusCode", resp.StatusCode)
+			}
+			return resp, nil
+		}
+
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		lastErr = fmt.Errorf("iotronic service returned status %d", resp.StatusCode)
+		if log != nil {
+			log.WarnWith("iotronic call returned non-2xx, retrying", "attempt", attempt, "statusCode", resp.StatusCode, "latency", latency)
+		}
+	}
+
+	return nil, lastErr
+}
+
+func doOnce(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return Client.Do(req)
+}
+
+// backoff returns an exponential delay with full jitter for the given
+// (1-indexed) retry attempt.
+func backoff(attempt int) time.Duration {
+	max := baseBackoff * time.Duration(1<<uint(attempt-1))
+	return time.Duration(rand.Int63n(int64(max)))
+}
+
+func wait(ctx context.Context, d time.Duration) error {
+	select {
+	case <-time.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}